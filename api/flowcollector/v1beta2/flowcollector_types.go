@@ -91,8 +91,28 @@ type FlowCollectorSpec struct {
 
 	// `networkPolicy` defines network policy settings for NetObserv components isolation.
 	NetworkPolicy NetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// `deprecatedFieldsPolicy` defines how the use of deprecated settings is handled when the `FlowCollector` is created or updated:<br>
+	// - `Warn` (default): deprecated settings are still mapped to their replacement when there is one, and a warning is returned.<br>
+	// - `Deny`: the `FlowCollector` is rejected when deprecated settings are added or changed, which helps enforcing their cleanup
+	// before they are removed from the API. Updates keeping them unchanged are still accepted, with a warning.<br>
+	// The deprecated settings that are checked are `spec.processor.kafkaConsumerReplicas` (when it is not the default value and
+	// `spec.processor.consumerReplicas` is not set), `spec.processor.kafkaConsumerAutoscaler`, `spec.processor.advanced.dropUnusedFields`
+	// (when it is `false`), `spec.consolePlugin.autoscaler`, the `IPFIX` agent type, the `Host` Loki authentication token,
+	// and the filter fields defined directly under `spec.agent.ebpf.flowFilter` instead of `rules`.
+	// +kubebuilder:validation:Enum:="Warn";"Deny"
+	// +kubebuilder:default:=Warn
+	// +optional
+	DeprecatedFieldsPolicy DeprecatedFieldsPolicy `json:"deprecatedFieldsPolicy,omitempty"`
 }
 
+type DeprecatedFieldsPolicy string
+
+const (
+	DeprecatedFieldsWarn DeprecatedFieldsPolicy = "Warn"
+	DeprecatedFieldsDeny DeprecatedFieldsPolicy = "Deny"
+)
+
 type NetworkPolicy struct {
 	// Deploys network policies on the namespaces used by NetObserv (main and privileged).
 	// These network policies better isolate the NetObserv components to prevent undesired connections from and to them.
@@ -240,6 +260,8 @@ type EBPFFlowFilterRule struct {
 	Protocol string `json:"protocol,omitempty"`
 
 	// `direction` optionally defines a direction to filter flows by. The available options are `Ingress` and `Egress`.
+	// It can only narrow the directions traced by the eBPF agent, as configured in `spec.agent.ebpf.direction`: a rule cannot
+	// match a direction that is not traced. When unset, the rule matches all traced directions.
 	// +kubebuilder:validation:Enum:="Ingress";"Egress"
 	// +optional
 	Direction string `json:"direction,omitempty"`
@@ -361,6 +383,17 @@ type FlowCollectorEBPF struct {
 	//+optional
 	ExcludeInterfaces []string `json:"excludeInterfaces"`
 
+	// `direction` selects the traffic directions that the eBPF agent traces on the network interfaces.<br>
+	// - `Both` (default) traces ingress and egress traffic.<br>
+	// - `Ingress` only traces ingress traffic.<br>
+	// - `Egress` only traces egress traffic.<br>
+	// Tracing a single direction reduces the agent overhead, at the cost of missing the flows only seen in the other direction.
+	// The `direction` of the flow filter rules can only narrow within the traced directions.
+	// +kubebuilder:validation:Enum:="Both";"Ingress";"Egress"
+	// +kubebuilder:default:=Both
+	// +optional
+	Direction EBPFDirection `json:"direction,omitempty"`
+
 	//+kubebuilder:validation:Enum=trace;debug;info;warn;error;fatal;panic
 	//+kubebuilder:default:=info
 	// `logLevel` defines the log level for the NetObserv eBPF Agent
@@ -411,6 +444,15 @@ type FlowCollectorEBPF struct {
 	FlowFilter *EBPFFlowFilter `json:"flowFilter,omitempty"`
 }
 
+// `EBPFDirection` defines the traffic directions traced by the eBPF agent.
+type EBPFDirection string
+
+const (
+	EBPFDirectionBoth    EBPFDirection = "Both"
+	EBPFDirectionIngress EBPFDirection = "Ingress"
+	EBPFDirectionEgress  EBPFDirection = "Egress"
+)
+
 // `FlowCollectorKafka` defines the desired Kafka config of FlowCollector
 type FlowCollectorKafka struct {
 	// Important: Run "make generate" to regenerate code after modifying this file
@@ -703,6 +745,13 @@ type FlowCollectorFLP struct {
 	// but with a lesser improvement in performance.
 	Filters []FLPFilterSet `json:"filters"`
 
+	// +optional
+	// `strippedFlowFields` is a list of flow fields, such as `DnsName`, that flowlogs-pipeline removes from all flows, regardless of the enabled features.
+	// They are removed by flowlogs-pipeline as the final step before writing to Loki, Prometheus or any exporter.
+	// As a consequence, they cannot be used in metrics or in the console plugin.
+	// When `spec.deploymentModel` is `Kafka`, they are only removed after reading from Kafka: the flows written by the eBPF agent to the Kafka topic still contain them.
+	StrippedFlowFields []string `json:"strippedFlowFields,omitempty"`
+
 	// Global configuration managing FlowCollectorSlices custom resources.
 	//+optional
 	SlicesConfig *SlicesConfig `json:"slicesConfig,omitempty"`
@@ -1323,6 +1372,14 @@ type AdvancedAgentConfig struct {
 	// Linux capabilities override, when not running as privileged. Default capabilities are BPF, PERFMON and NET_ADMIN.
 	// +optional
 	CapOverride []string `json:"capOverride,omitempty"`
+
+	// `maxProcessingCPUs` limits the number of CPUs used by the eBPF agent for its user space processing, such as on small edge nodes
+	// where the agent competes with workloads. It sets the `GOMAXPROCS` environment variable, independently from the container CPU limit,
+	// and is ignored when `GOMAXPROCS` is set in `env`. The effective value is logged by the operator when it deploys the eBPF agent.
+	// It does not limit the CPU consumed by the eBPF programs, which run in the kernel in the context of the observed traffic.
+	//+kubebuilder:validation:Minimum=1
+	// +optional
+	MaxProcessingCPUs *int32 `json:"maxProcessingCPUs,omitempty"`
 }
 
 // `AdvancedProcessorConfig` allows tweaking some aspects of the internal configuration of the processor.
@@ -28,6 +28,9 @@ var (
 		NetworkEvents: "4.19.0",
 		EbpfManager:   "4.19.0",
 	}
+	// FlowFieldsUsage returns the flow fields used by the metrics and by the console plugin columns, mapped to where they are used.
+	// It is set by the manager, as these definitions are not available from this package.
+	FlowFieldsUsage func(context.Context, *FlowCollectorSpec) map[string][]string
 )
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
@@ -37,9 +40,9 @@ func (r *FlowCollector) ValidateCreate(ctx context.Context, fc *FlowCollector) (
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *FlowCollector) ValidateUpdate(ctx context.Context, _, fc *FlowCollector) (admission.Warnings, error) {
+func (r *FlowCollector) ValidateUpdate(ctx context.Context, oldFc, fc *FlowCollector) (admission.Warnings, error) {
 	log.Info("validate update", "name", r.Name)
-	return r.Validate(ctx, fc)
+	return r.validate(ctx, oldFc, fc)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -48,19 +51,29 @@ func (r *FlowCollector) ValidateDelete(_ context.Context, _ *FlowCollector) (adm
 	return nil, nil
 }
 
-func (r *FlowCollector) Validate(_ context.Context, fc *FlowCollector) (admission.Warnings, error) {
+func (r *FlowCollector) Validate(ctx context.Context, fc *FlowCollector) (admission.Warnings, error) {
+	return r.validate(ctx, nil, fc)
+}
+
+func (r *FlowCollector) validate(ctx context.Context, oldFc, fc *FlowCollector) (admission.Warnings, error) {
 	v := validator{fc: &fc.Spec}
+	if oldFc != nil {
+		v.oldFc = &oldFc.Spec
+	}
 	v.validateDeploymentModel()
 	v.validateNetPol()
 	v.validateAgent()
 	v.validateFLP()
+	v.validateStrippedFields(ctx)
 	v.warnLogLevels()
 	v.warnLokiDemo()
+	v.validateDeprecatedFields()
 	return v.warnings, errors.Join(v.errors...)
 }
 
 type validator struct {
 	fc       *FlowCollectorSpec
+	oldFc    *FlowCollectorSpec
 	warnings admission.Warnings
 	errors   []error
 }
@@ -80,6 +93,56 @@ func (v *validator) warnLokiDemo() {
 	}
 }
 
+type deprecatedField struct {
+	msg   string
+	value any
+}
+
+func deprecatedFields(fc *FlowCollectorSpec) []deprecatedField {
+	var used []deprecatedField
+	if fc.Processor.ConsumerReplicas == nil && fc.Processor.KafkaConsumerReplicas != nil && *fc.Processor.KafkaConsumerReplicas != DefaultFLPReplicas {
+		used = append(used, deprecatedField{"spec.processor.kafkaConsumerReplicas is deprecated, use spec.processor.consumerReplicas instead", *fc.Processor.KafkaConsumerReplicas})
+	}
+	if fc.Processor.KafkaConsumerAutoscaler.IsHPAEnabled() {
+		used = append(used, deprecatedField{"spec.processor.kafkaConsumerAutoscaler is deprecated, configure an autoscaler of your choice and set spec.processor.unmanagedReplicas instead", fc.Processor.KafkaConsumerAutoscaler})
+	}
+	if fc.Processor.Advanced != nil && fc.Processor.Advanced.DropUnusedFields != nil && !*fc.Processor.Advanced.DropUnusedFields {
+		used = append(used, deprecatedField{"spec.processor.advanced.dropUnusedFields is deprecated, this setting is not used anymore", false})
+	}
+	if fc.ConsolePlugin.Autoscaler.IsHPAEnabled() {
+		used = append(used, deprecatedField{"spec.consolePlugin.autoscaler is deprecated, configure an autoscaler of your choice and set spec.consolePlugin.unmanagedReplicas instead", fc.ConsolePlugin.Autoscaler})
+	}
+	if fc.Agent.Type == AgentIPFIX {
+		used = append(used, deprecatedField{"spec.agent.type: IPFIX is deprecated, the IPFIX agent is not supported anymore, use eBPF instead", fc.Agent.Type})
+	}
+	if fc.Loki.Mode == LokiModeManual && fc.Loki.Manual.AuthToken == LokiAuthUseHostToken {
+		used = append(used, deprecatedField{"spec.loki.manual.authToken: Host is deprecated, use Forward or Disabled instead", fc.Loki.Manual.AuthToken})
+	}
+	if fc.Agent.EBPF.FlowFilter != nil && !reflect.DeepEqual(fc.Agent.EBPF.FlowFilter.EBPFFlowFilterRule, EBPFFlowFilterRule{}) {
+		used = append(used, deprecatedField{"the filter fields defined directly under spec.agent.ebpf.flowFilter are deprecated, use spec.agent.ebpf.flowFilter.rules instead", fc.Agent.EBPF.FlowFilter.EBPFFlowFilterRule})
+	}
+	return used
+}
+
+func (v *validator) validateDeprecatedFields() {
+	var previous []deprecatedField
+	if v.oldFc != nil {
+		previous = deprecatedFields(v.oldFc)
+	}
+	for _, field := range deprecatedFields(v.fc) {
+		// On update, a deprecated setting that is kept unchanged is not denied, so that the FlowCollector can still be updated,
+		// such as by the operator itself, until it is cleaned up
+		unchanged := slices.ContainsFunc(previous, func(p deprecatedField) bool {
+			return p.msg == field.msg && reflect.DeepEqual(p.value, field.value)
+		})
+		if v.fc.DeprecatedFieldsPolicy == DeprecatedFieldsDeny && !unchanged {
+			v.errors = append(v.errors, fmt.Errorf("%s (spec.deprecatedFieldsPolicy is Deny)", field.msg))
+		} else {
+			v.warnings = append(v.warnings, field.msg)
+		}
+	}
+}
+
 func (v *validator) validateDeploymentModel() {
 	if CurrentClusterInfo != nil {
 		n, err := CurrentClusterInfo.GetNbNodes()
@@ -159,24 +222,31 @@ func (v *validator) validateAgentFilter(f *EBPFFlowFilterRule) {
 			v.errors = append(v.errors, err)
 		}
 	}
-	hasPorts := f.Ports.IntVal > 0 || f.Ports.StrVal != ""
+	hasPorts := f.Ports.IntVal != 0 || f.Ports.StrVal != ""
 	if hasPorts {
 		if err := validateFilterPortConfig(f.Ports); err != nil {
 			v.errors = append(v.errors, err)
 		}
 	}
-	hasSrcPorts := f.SourcePorts.IntVal > 0 || f.SourcePorts.StrVal != ""
+	hasSrcPorts := f.SourcePorts.IntVal != 0 || f.SourcePorts.StrVal != ""
 	if hasSrcPorts {
 		if err := validateFilterPortConfig(f.SourcePorts); err != nil {
 			v.errors = append(v.errors, err)
 		}
 	}
-	hasDstPorts := f.DestPorts.IntVal > 0 || f.DestPorts.StrVal != ""
+	hasDstPorts := f.DestPorts.IntVal != 0 || f.DestPorts.StrVal != ""
 	if hasDstPorts {
 		if err := validateFilterPortConfig(f.DestPorts); err != nil {
 			v.errors = append(v.errors, err)
 		}
 	}
+	if f.Direction != "" {
+		traced := v.fc.Agent.EBPF.Direction
+		if traced != "" && traced != EBPFDirectionBoth && string(traced) != f.Direction {
+			v.errors = append(v.errors, fmt.Errorf("cannot configure agent filter with direction %s: only %s traffic is traced by spec.agent.ebpf.direction; "+
+				"set spec.agent.ebpf.direction to Both or %s, or remove the rule direction", f.Direction, traced, f.Direction))
+		}
+	}
 	if hasPorts && hasSrcPorts {
 		v.errors = append(v.errors, errors.New("cannot configure agent filter with ports and sourcePorts, they are mutually exclusive"))
 	}
@@ -187,38 +257,44 @@ func (v *validator) validateAgentFilter(f *EBPFFlowFilterRule) {
 
 func validateFilterPortConfig(value intstr.IntOrString) error {
 	if value.Type == intstr.Int {
+		if value.IntVal < 1 || value.IntVal > 65535 {
+			return fmt.Errorf("invalid port %d: expected a value between 1 and 65535", value.IntVal)
+		}
 		return nil
 	}
-	sVal := value.String()
-	if strings.Contains(sVal, "-") {
+	// Whitespaces are allowed around ports, and removed the same way when configuring the agent
+	sVal := TrimPorts(value.String())
+	isRange := strings.Contains(sVal, "-")
+	isCouple := strings.Contains(sVal, ",")
+	if isRange && isCouple {
+		return fmt.Errorf("invalid ports %q: a ports range ('-') and a ports couple (',') cannot be mixed", sVal)
+	}
+	if isRange {
 		ps := strings.SplitN(sVal, "-", 2)
-		if len(ps) != 2 {
-			return fmt.Errorf("invalid ports range: expected two integers separated by '-' but found %s", sVal)
-		}
 		start, err := validatePortString(ps[0])
 		if err != nil {
-			return fmt.Errorf("start port in range: %w", err)
+			return fmt.Errorf("start port in range %q: %w", sVal, err)
 		}
 		end, err := validatePortString(ps[1])
 		if err != nil {
-			return fmt.Errorf("end port in range: %w", err)
+			return fmt.Errorf("end port in range %q: %w", sVal, err)
 		}
 		if start >= end {
-			return fmt.Errorf("invalid port range: start is greater or equal to end")
+			return fmt.Errorf("invalid port range %q: start is greater or equal to end", sVal)
 		}
 		return nil
-	} else if strings.Contains(sVal, ",") {
+	} else if isCouple {
 		ps := strings.Split(sVal, ",")
 		if len(ps) != 2 {
 			return fmt.Errorf("invalid ports couple: expected two integers separated by ',' but found %s", sVal)
 		}
 		_, err := validatePortString(ps[0])
 		if err != nil {
-			return fmt.Errorf("first port: %w", err)
+			return fmt.Errorf("first port in couple %q: %w", sVal, err)
 		}
 		_, err = validatePortString(ps[1])
 		if err != nil {
-			return fmt.Errorf("second port: %w", err)
+			return fmt.Errorf("second port in couple %q: %w", sVal, err)
 		}
 		return nil
 	}
@@ -233,7 +309,7 @@ func validateFilterPortConfig(value intstr.IntOrString) error {
 func validatePortString(s string) (uint16, error) {
 	p, err := strconv.ParseUint(s, 10, 16)
 	if err != nil {
-		return 0, fmt.Errorf("invalid port number %w", err)
+		return 0, fmt.Errorf("invalid port number %q: %w", s, err)
 	}
 	if p == 0 {
 		return 0, fmt.Errorf("invalid port 0")
@@ -249,6 +325,24 @@ func (v *validator) validateFLP() {
 	v.validateFLPMetricsForAlerts()
 }
 
+func (v *validator) validateStrippedFields(ctx context.Context) {
+	if len(v.fc.Processor.StrippedFlowFields) == 0 {
+		return
+	}
+	if v.fc.UseKafka() {
+		v.warnings = append(v.warnings, "spec.processor.strippedFlowFields are removed by flowlogs-pipeline after reading from Kafka: the flows written by the eBPF agent to the Kafka topic still contain them")
+	}
+	if FlowFieldsUsage == nil {
+		return
+	}
+	usage := FlowFieldsUsage(ctx, v.fc)
+	for _, f := range v.fc.Processor.StrippedFlowFields {
+		if usedBy := usage[f]; len(usedBy) > 0 {
+			v.warnings = append(v.warnings, fmt.Sprintf("The flow field %s is listed in spec.processor.strippedFlowFields, so it is always missing from: %s", f, strings.Join(usedBy, ", ")))
+		}
+	}
+}
+
 func (v *validator) validateScheduling() {
 	if v.fc.DeploymentModel == DeploymentModelDirect {
 		// In direct mode, agent and FLP scheduling should be consistent, to ensure the 1-1 relation
@@ -411,6 +411,30 @@ func TestValidateAgent(t *testing.T) {
 			},
 			expectedError: "expected two integers",
 		},
+		{
+			name: "FlowFilter expect invalid negative ports",
+			fc: &FlowCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+				Spec: FlowCollectorSpec{
+					Agent: FlowCollectorAgent{
+						Type: AgentEBPF,
+						EBPF: FlowCollectorEBPF{
+							FlowFilter: &EBPFFlowFilter{
+								Enable: ptr.To(true),
+								Rules: []EBPFFlowFilterRule{
+									{
+										SourcePorts: intstr.FromInt(-80),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError: "invalid port -80",
+		},
 		{
 			name: "FlowFilter expect invalid CIDR",
 			fc: &FlowCollector{
@@ -435,6 +459,157 @@ func TestValidateAgent(t *testing.T) {
 			},
 			expectedError: "invalid CIDR",
 		},
+		{
+			name: "Valid filter direction with both directions traced",
+			fc: &FlowCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+				Spec: FlowCollectorSpec{
+					Agent: FlowCollectorAgent{
+						Type: AgentEBPF,
+						EBPF: FlowCollectorEBPF{
+							Direction: EBPFDirectionBoth,
+							FlowFilter: &EBPFFlowFilter{
+								Enable: ptr.To(true),
+								Rules: []EBPFFlowFilterRule{
+									{
+										CIDR:      "0.0.0.0/0",
+										Direction: "Ingress",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Valid filter direction with the traced direction unset",
+			fc: &FlowCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+				Spec: FlowCollectorSpec{
+					Agent: FlowCollectorAgent{
+						Type: AgentEBPF,
+						EBPF: FlowCollectorEBPF{
+							FlowFilter: &EBPFFlowFilter{
+								Enable: ptr.To(true),
+								Rules: []EBPFFlowFilterRule{
+									{
+										CIDR:      "0.0.0.0/0",
+										Direction: "Ingress",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Valid filter ingress direction with only ingress traced",
+			fc: &FlowCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+				Spec: FlowCollectorSpec{
+					Agent: FlowCollectorAgent{
+						Type: AgentEBPF,
+						EBPF: FlowCollectorEBPF{
+							Direction: EBPFDirectionIngress,
+							FlowFilter: &EBPFFlowFilter{
+								Enable: ptr.To(true),
+								Rules: []EBPFFlowFilterRule{
+									{
+										CIDR:      "0.0.0.0/0",
+										Direction: "Ingress",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Valid filter direction within the traced direction",
+			fc: &FlowCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+				Spec: FlowCollectorSpec{
+					Agent: FlowCollectorAgent{
+						Type: AgentEBPF,
+						EBPF: FlowCollectorEBPF{
+							Direction: EBPFDirectionEgress,
+							FlowFilter: &EBPFFlowFilter{
+								Enable: ptr.To(true),
+								Rules: []EBPFFlowFilterRule{
+									{
+										CIDR:      "0.0.0.0/0",
+										Direction: "Egress",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid filter ingress direction with only egress traced",
+			fc: &FlowCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+				Spec: FlowCollectorSpec{
+					Agent: FlowCollectorAgent{
+						Type: AgentEBPF,
+						EBPF: FlowCollectorEBPF{
+							Direction: EBPFDirectionEgress,
+							FlowFilter: &EBPFFlowFilter{
+								Enable: ptr.To(true),
+								Rules: []EBPFFlowFilterRule{
+									{
+										CIDR:      "0.0.0.0/0",
+										Direction: "Ingress",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError: `cannot configure agent filter with direction Ingress: only Egress traffic is traced by spec.agent.ebpf.direction`,
+		},
+		{
+			name: "Invalid filter egress direction with only ingress traced",
+			fc: &FlowCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+				Spec: FlowCollectorSpec{
+					Agent: FlowCollectorAgent{
+						Type: AgentEBPF,
+						EBPF: FlowCollectorEBPF{
+							Direction: EBPFDirectionIngress,
+							FlowFilter: &EBPFFlowFilter{
+								Enable: ptr.To(true),
+								Rules: []EBPFFlowFilterRule{
+									{
+										CIDR:      "0.0.0.0/0",
+										Direction: "Egress",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError: `cannot configure agent filter with direction Egress: only Ingress traffic is traced by spec.agent.ebpf.direction`,
+		},
 	}
 
 	CurrentClusterInfo = &cluster.Info{}
@@ -452,6 +627,174 @@ func TestValidateAgent(t *testing.T) {
 	}
 }
 
+func TestValidateFilterPortConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		ports         intstr.IntOrString
+		expectedError string
+	}{
+		{name: "single port", ports: intstr.FromInt(80)},
+		{name: "single port as string", ports: intstr.FromString("80")},
+		{name: "range", ports: intstr.FromString("8000-8010")},
+		{name: "range with spaces", ports: intstr.FromString("8000 - 8010")},
+		{name: "couple", ports: intstr.FromString("8000,8010")},
+		{name: "couple with spaces", ports: intstr.FromString("8000, 8010")},
+		{name: "single port with tabs", ports: intstr.FromString("\t80 ")},
+		{name: "couple with tabs", ports: intstr.FromString("8000,\t8010")},
+		{name: "single port out of bounds", ports: intstr.FromInt(70000), expectedError: "invalid port 70000"},
+		{name: "negative single port", ports: intstr.FromInt(-80), expectedError: "invalid port -80"},
+		{name: "spaces inside a port", ports: intstr.FromString("80 80"), expectedError: `invalid port number "80 80"`},
+		{name: "spaces inside a range start", ports: intstr.FromString("80 81-90"), expectedError: `start port in range "80 81-90"`},
+		{name: "single port not a number", ports: intstr.FromString("http"), expectedError: `invalid port number "http"`},
+		{name: "range then couple", ports: intstr.FromString("8000-8010,8020"), expectedError: `invalid ports "8000-8010,8020": a ports range ('-') and a ports couple (',') cannot be mixed`},
+		{name: "couple then range", ports: intstr.FromString("8000,8010-8020"), expectedError: `invalid ports "8000,8010-8020": a ports range ('-') and a ports couple (',') cannot be mixed`},
+		{name: "range missing end", ports: intstr.FromString("8000-"), expectedError: `end port in range "8000-"`},
+		{name: "range missing start", ports: intstr.FromString("-8010"), expectedError: `start port in range "-8010"`},
+		{name: "range with three ports", ports: intstr.FromString("8000-8010-8020"), expectedError: `end port in range "8000-8010-8020"`},
+		{name: "range reversed", ports: intstr.FromString("8010-8000"), expectedError: `invalid port range "8010-8000": start is greater or equal to end`},
+		{name: "couple missing second port", ports: intstr.FromString("8000,"), expectedError: `second port in couple "8000,"`},
+		{name: "couple with three ports", ports: intstr.FromString("8000,8010,8020"), expectedError: "expected two integers separated by ',' but found 8000,8010,8020"},
+	}
+
+	for _, test := range tests {
+		err := validateFilterPortConfig(test.ports)
+		if test.expectedError == "" {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.ErrorContains(t, err, test.expectedError, test.name)
+		}
+	}
+}
+
+func TestValidateDeprecatedFields(t *testing.T) {
+	spec := FlowCollectorSpec{
+		Processor: FlowCollectorFLP{
+			KafkaConsumerReplicas: ptr.To(int32(5)),
+		},
+		ConsolePlugin: FlowCollectorConsolePlugin{
+			Autoscaler: FlowCollectorHPA{Status: HPAStatusEnabled},
+		},
+	}
+
+	// Lenient by default
+	v := validator{fc: &spec}
+	v.validateDeprecatedFields()
+	assert.Empty(t, v.errors)
+	assert.Equal(t, admission.Warnings{
+		"spec.processor.kafkaConsumerReplicas is deprecated, use spec.processor.consumerReplicas instead",
+		"spec.consolePlugin.autoscaler is deprecated, configure an autoscaler of your choice and set spec.consolePlugin.unmanagedReplicas instead",
+	}, v.warnings)
+
+	// The replacement takes precedence
+	spec.Processor.ConsumerReplicas = ptr.To(int32(5))
+	v = validator{fc: &spec}
+	v.validateDeprecatedFields()
+	assert.Len(t, v.warnings, 1)
+
+	// Strict mode
+	spec.DeprecatedFieldsPolicy = DeprecatedFieldsDeny
+	v = validator{fc: &spec}
+	v.validateDeprecatedFields()
+	assert.Empty(t, v.warnings)
+	assert.Len(t, v.errors, 1)
+	assert.ErrorContains(t, v.errors[0], "spec.consolePlugin.autoscaler is deprecated")
+
+	// Default values are not reported
+	spec = FlowCollectorSpec{
+		Agent: FlowCollectorAgent{Type: AgentEBPF},
+		Processor: FlowCollectorFLP{
+			KafkaConsumerReplicas: ptr.To(DefaultFLPReplicas),
+			Advanced:              &AdvancedProcessorConfig{DropUnusedFields: ptr.To(true)},
+		},
+	}
+	v = validator{fc: &spec}
+	v.validateDeprecatedFields()
+	assert.Empty(t, v.warnings)
+
+	// IPFIX agent and dropUnusedFields
+	spec.Agent.Type = AgentIPFIX
+	spec.Processor.Advanced.DropUnusedFields = ptr.To(false)
+	v = validator{fc: &spec}
+	v.validateDeprecatedFields()
+	assert.Equal(t, admission.Warnings{
+		"spec.processor.advanced.dropUnusedFields is deprecated, this setting is not used anymore",
+		"spec.agent.type: IPFIX is deprecated, the IPFIX agent is not supported anymore, use eBPF instead",
+	}, v.warnings)
+}
+
+func TestValidateDeprecatedFieldsOnUpdate(t *testing.T) {
+	oldFc := FlowCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: FlowCollectorSpec{
+			DeprecatedFieldsPolicy: DeprecatedFieldsDeny,
+			Processor: FlowCollectorFLP{
+				KafkaConsumerReplicas: ptr.To(int32(5)),
+			},
+		},
+	}
+	r := FlowCollector{}
+
+	// Kept unchanged, such as when the operator adds a finalizer: warn only
+	fc := oldFc.DeepCopy()
+	fc.Finalizers = []string{"flows.netobserv.io/finalizer"}
+	warnings, err := r.ValidateUpdate(context.TODO(), &oldFc, fc)
+	assert.NoError(t, err)
+	assert.Contains(t, warnings, "spec.processor.kafkaConsumerReplicas is deprecated, use spec.processor.consumerReplicas instead")
+
+	// Changed value: deny
+	fc.Spec.Processor.KafkaConsumerReplicas = ptr.To(int32(6))
+	_, err = r.ValidateUpdate(context.TODO(), &oldFc, fc)
+	assert.ErrorContains(t, err, "spec.processor.kafkaConsumerReplicas is deprecated")
+
+	// Newly used: deny
+	fc = oldFc.DeepCopy()
+	fc.Spec.ConsolePlugin.Autoscaler = FlowCollectorHPA{Status: HPAStatusEnabled}
+	_, err = r.ValidateUpdate(context.TODO(), &oldFc, fc)
+	assert.ErrorContains(t, err, "spec.consolePlugin.autoscaler is deprecated")
+	assert.NotContains(t, err.Error(), "kafkaConsumerReplicas")
+
+	// Previously compliant: deny
+	compliant := FlowCollector{Spec: FlowCollectorSpec{DeprecatedFieldsPolicy: DeprecatedFieldsDeny}}
+	_, err = r.ValidateUpdate(context.TODO(), &compliant, &oldFc)
+	assert.ErrorContains(t, err, "spec.processor.kafkaConsumerReplicas is deprecated")
+}
+
+func TestValidateStrippedFields(t *testing.T) {
+	FlowFieldsUsage = func(_ context.Context, _ *FlowCollectorSpec) map[string][]string {
+		return map[string][]string{
+			"DnsName": {"console plugin column DNS name"},
+			"Flags":   {"metric node_flows_total", "console plugin column Flags"},
+		}
+	}
+	defer func() { FlowFieldsUsage = nil }()
+
+	spec := FlowCollectorSpec{
+		Processor: FlowCollectorFLP{
+			StrippedFlowFields: []string{"Flags", "IcmpType"},
+		},
+	}
+	v := validator{fc: &spec}
+	v.validateStrippedFields(context.TODO())
+	assert.Empty(t, v.errors)
+	assert.Equal(t, admission.Warnings{
+		"The flow field Flags is listed in spec.processor.strippedFlowFields, so it is always missing from: metric node_flows_total, console plugin column Flags",
+	}, v.warnings)
+
+	// Kafka: stripped after reading from the topic
+	spec.DeploymentModel = DeploymentModelKafka
+	spec.Processor.StrippedFlowFields = []string{"IcmpType"}
+	v = validator{fc: &spec}
+	v.validateStrippedFields(context.TODO())
+	assert.Len(t, v.warnings, 1)
+	assert.Contains(t, v.warnings[0], "the flows written by the eBPF agent to the Kafka topic still contain them")
+
+	// Nothing stripped
+	spec.Processor.StrippedFlowFields = nil
+	v = validator{fc: &spec}
+	v.validateStrippedFields(context.TODO())
+	assert.Empty(t, v.warnings)
+}
+
 func TestValidateConntrack(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -1,11 +1,21 @@
 package v1beta2
 
 import (
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 )
 
+var portsSeparatorSpaces = regexp.MustCompile(`\s*([-,])\s*`)
+
+// TrimPorts removes the whitespaces around the ports of a single port, a ports range or a ports couple.
+// Whitespaces inside a port are kept, so that it fails to parse.
+func TrimPorts(ports string) string {
+	return strings.TrimSpace(portsSeparatorSpaces.ReplaceAllString(ports, "$1"))
+}
+
 func (spec *FlowCollectorSpec) GetNamespace() string {
 	if spec.Namespace != "" {
 		return spec.Namespace
@@ -185,13 +195,16 @@ func (spec *FlowCollectorSpec) DeployNetworkPolicyOtherCNI() bool {
 	return spec.NetworkPolicy.Enable != nil && *spec.NetworkPolicy.Enable
 }
 
+// DefaultFLPReplicas is the default number of flowlogs-pipeline replicas, when it is not deployed as a DaemonSet.
+const DefaultFLPReplicas int32 = 3
+
 func (spec *FlowCollectorFLP) GetFLPReplicas() int32 {
 	if spec.ConsumerReplicas != nil {
 		return *spec.ConsumerReplicas
 	} else if spec.KafkaConsumerReplicas != nil {
 		return *spec.KafkaConsumerReplicas
 	}
-	return 3
+	return DefaultFLPReplicas
 }
 
 func (spec *FlowCollectorHPA) IsHPAEnabled() bool {
@@ -47,6 +47,11 @@ func (in *AdvancedAgentConfig) DeepCopyInto(out *AdvancedAgentConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaxProcessingCPUs != nil {
+		in, out := &in.MaxProcessingCPUs, &out.MaxProcessingCPUs
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedAgentConfig.
@@ -698,6 +703,11 @@ func (in *FlowCollectorFLP) DeepCopyInto(out *FlowCollectorFLP) {
 		*out = make([]FLPFilterSet, len(*in))
 		copy(*out, *in)
 	}
+	if in.StrippedFlowFields != nil {
+		in, out := &in.StrippedFlowFields, &out.StrippedFlowFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SlicesConfig != nil {
 		in, out := &in.SlicesConfig, &out.SlicesConfig
 		*out = new(SlicesConfig)
@@ -153,3 +153,18 @@ func GetStaticFrontendConfig() (FrontendConfig, error) {
 	}
 	return *staticFrontendConfig, nil
 }
+
+// GetColumnsFieldsUsage returns the flow fields displayed by the columns, mapped to the names of the columns displaying them.
+func (c *FrontendConfig) GetColumnsFieldsUsage() map[string][]string {
+	usage := make(map[string][]string)
+	for _, col := range c.Columns {
+		fields := col.Fields
+		if col.Field != "" {
+			fields = append([]string{col.Field}, fields...)
+		}
+		for _, f := range fields {
+			usage[f] = append(usage[f], col.Name)
+		}
+	}
+	return usage
+}
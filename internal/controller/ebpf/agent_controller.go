@@ -32,7 +32,9 @@ import (
 const (
 	envCacheActiveTimeout         = "CACHE_ACTIVE_TIMEOUT"
 	envCacheMaxFlows              = "CACHE_MAX_FLOWS"
+	envGoMaxProcs                 = "GOMAXPROCS"
 	envExcludeInterfaces          = "EXCLUDE_INTERFACES"
+	envDirection                  = "DIRECTION"
 	envInterfaces                 = "INTERFACES"
 	envAgentIP                    = "AGENT_IP"
 	envFlowsTargetHost            = "TARGET_HOST"
@@ -161,11 +163,11 @@ func (c *AgentController) Reconcile(ctx context.Context, target *flowslatest.Flo
 
 	switch helper.DaemonSetChanged(current, desired) {
 	case helper.ActionCreate:
-		rlog.Info("action: create agent")
+		rlog.Info("action: create agent", envGoMaxProcs, effectiveGoMaxProcs(desired))
 		c.Status.SetCreatingDaemonSet(desired)
 		err = c.CreateOwned(ctx, desired)
 	case helper.ActionUpdate:
-		rlog.Info("action: update agent")
+		rlog.Info("action: update agent", envGoMaxProcs, effectiveGoMaxProcs(desired))
 		err = c.UpdateIfOwned(ctx, current, desired)
 	default:
 		rlog.Info("action: nothing to do")
@@ -198,6 +200,16 @@ func (c *AgentController) current(ctx context.Context) (*v1.DaemonSet, error) {
 	return &agentDS, nil
 }
 
+// effectiveGoMaxProcs returns the GOMAXPROCS value set on the agent container, or "default" when the Go runtime picks it.
+func effectiveGoMaxProcs(ds *v1.DaemonSet) string {
+	for _, env := range ds.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == envGoMaxProcs {
+			return env.Value
+		}
+	}
+	return "default"
+}
+
 func newHostPathType(pathType corev1.HostPathType) *corev1.HostPathType {
 	hostPathType := new(corev1.HostPathType)
 	*hostPathType = corev1.HostPathType(pathType)
@@ -604,12 +616,14 @@ func mapFlowFilterToFilter(filter *flowslatest.EBPFFlowFilter) ebpfconfig.FlowFi
 
 func processPorts(ports intstr.IntOrString, single *int32, list *string, rangeField *string) {
 	if ports.Type == intstr.String {
-		portStr := ports.String()
+		// Whitespaces are allowed around ports, but not expected by the agent
+		portStr := flowslatest.TrimPorts(ports.String())
 		if strings.Contains(portStr, "-") {
 			*rangeField = portStr
-		}
-		if strings.Contains(portStr, ",") {
+		} else if strings.Contains(portStr, ",") {
 			*list = portStr
+		} else if p, err := strconv.ParseUint(portStr, 10, 16); err == nil {
+			*single = int32(p)
 		}
 	} else if ports.Type == intstr.Int {
 		*single = int32(ports.IntValue())
@@ -657,6 +671,7 @@ func (c *AgentController) securityContext(coll *flowslatest.FlowCollector) *core
 // nolint:cyclop
 func getEnvConfig(coll *flowslatest.FlowCollector, cinfo *cluster.Info) []corev1.EnvVar {
 	var config []corev1.EnvVar
+	advancedConfig := helper.GetAdvancedAgentConfig(coll.Spec.Agent.EBPF.Advanced)
 
 	if coll.Spec.Agent.EBPF.CacheActiveTimeout != "" {
 		config = append(config, corev1.EnvVar{
@@ -693,6 +708,13 @@ func getEnvConfig(coll *flowslatest.FlowCollector, cinfo *cluster.Info) []corev1
 		})
 	}
 
+	switch coll.Spec.Agent.EBPF.Direction {
+	case flowslatest.EBPFDirectionIngress:
+		config = append(config, corev1.EnvVar{Name: envDirection, Value: ebpfconfig.DirectionIngress})
+	case flowslatest.EBPFDirectionEgress:
+		config = append(config, corev1.EnvVar{Name: envDirection, Value: ebpfconfig.DirectionEgress})
+	}
+
 	sampling := coll.Spec.Agent.EBPF.Sampling
 	if sampling != nil && *sampling > 0 {
 		config = append(config, corev1.EnvVar{
@@ -738,6 +760,10 @@ func getEnvConfig(coll *flowslatest.FlowCollector, cinfo *cluster.Info) []corev1
 	// set GOMEMLIMIT which allows specifying a soft memory cap to force GC when resource limit is reached to prevent OOM
 	config = helper.EnvFromReqsLimits(config, &coll.Spec.Agent.EBPF.Resources)
 
+	if _, overridden := advancedConfig.Env[envGoMaxProcs]; advancedConfig.MaxProcessingCPUs != nil && !overridden {
+		config = append(config, corev1.EnvVar{Name: envGoMaxProcs, Value: strconv.Itoa(int(*advancedConfig.MaxProcessingCPUs))})
+	}
+
 	if coll.Spec.Agent.EBPF.IsPktDropEnabled() {
 		config = append(config, corev1.EnvVar{
 			Name:  envEnablePktDrop,
@@ -808,7 +834,6 @@ func getEnvConfig(coll *flowslatest.FlowCollector, cinfo *cluster.Info) []corev1
 		envPreferredInterface:   defaultPreferredInterface,
 		envAttachMode:           defaultAttach,
 	}
-	advancedConfig := helper.GetAdvancedAgentConfig(coll.Spec.Agent.EBPF.Advanced)
 	moreConfig := helper.BuildEnvFromDefaults(advancedConfig.Env, defaults)
 	config = append(config, moreConfig...)
 
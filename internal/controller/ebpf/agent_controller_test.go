@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 )
 
@@ -128,6 +129,7 @@ func TestGetEnvConfig_WithOverrides(t *testing.T) {
 							"NETWORK_EVENTS_MONITORING_GROUP_ID": "any",
 							"TC_ATTACH_MODE":                     "any",
 						},
+						MaxProcessingCPUs: ptr.To(int32(2)),
 					},
 					Resources: corev1.ResourceRequirements{
 						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("800Mi")},
@@ -152,6 +154,7 @@ func TestGetEnvConfig_WithOverrides(t *testing.T) {
 	env := getEnvConfig(&fc, &cluster.Info{})
 	assert.Equal(t, []corev1.EnvVar{
 		{Name: "GOMEMLIMIT", Value: "754974720"},
+		{Name: "GOMAXPROCS", Value: "2"},
 		{Name: "FLOW_FILTER_RULES", Value: `[{"ip_cidr":"0.0.0.0/0","action":"Accept"}]`},
 		{Name: "AGENT_IP", Value: "",
 			ValueFrom: &corev1.EnvVarSource{
@@ -167,6 +170,59 @@ func TestGetEnvConfig_WithOverrides(t *testing.T) {
 	}, env)
 }
 
+func TestGetEnvConfig_GoMaxProcsEnvPrecedence(t *testing.T) {
+	fc := flowslatest.FlowCollector{
+		Spec: flowslatest.FlowCollectorSpec{
+			Agent: flowslatest.FlowCollectorAgent{
+				EBPF: flowslatest.FlowCollectorEBPF{
+					Advanced: &flowslatest.AdvancedAgentConfig{
+						Env:               map[string]string{"GOMAXPROCS": "4"},
+						MaxProcessingCPUs: ptr.To(int32(2)),
+					},
+				},
+			},
+		},
+	}
+
+	env := getEnvConfig(&fc, &cluster.Info{})
+	var goMaxProcs []string
+	for _, e := range env {
+		if e.Name == "GOMAXPROCS" {
+			goMaxProcs = append(goMaxProcs, e.Value)
+		}
+	}
+	assert.Equal(t, []string{"4"}, goMaxProcs)
+}
+
+func TestGetEnvConfig_Direction(t *testing.T) {
+	tests := []struct {
+		direction flowslatest.EBPFDirection
+		expected  []string
+	}{
+		{direction: "", expected: nil},
+		{direction: flowslatest.EBPFDirectionBoth, expected: nil},
+		{direction: flowslatest.EBPFDirectionIngress, expected: []string{"ingress"}},
+		{direction: flowslatest.EBPFDirectionEgress, expected: []string{"egress"}},
+	}
+
+	for _, test := range tests {
+		fc := flowslatest.FlowCollector{
+			Spec: flowslatest.FlowCollectorSpec{
+				Agent: flowslatest.FlowCollectorAgent{
+					EBPF: flowslatest.FlowCollectorEBPF{Direction: test.direction},
+				},
+			},
+		}
+		var direction []string
+		for _, e := range getEnvConfig(&fc, &cluster.Info{}) {
+			if e.Name == "DIRECTION" {
+				direction = append(direction, e.Value)
+			}
+		}
+		assert.Equal(t, test.expected, direction, string(test.direction))
+	}
+}
+
 func TestGetEnvConfig_OCP4_14(t *testing.T) {
 	fc := flowslatest.FlowCollector{
 		Spec: flowslatest.FlowCollectorSpec{
@@ -268,3 +324,31 @@ func TestNetworkEventsOVNMount(t *testing.T) {
 	assert.Equal(t, "var-run-ovn", ds.Spec.Template.Spec.Volumes[2].Name)
 	assert.Equal(t, "/foo/bar", ds.Spec.Template.Spec.Volumes[2].HostPath.Path)
 }
+
+func TestProcessPorts(t *testing.T) {
+	tests := []struct {
+		ports          intstr.IntOrString
+		expectedSingle int32
+		expectedList   string
+		expectedRange  string
+	}{
+		{ports: intstr.FromInt(80), expectedSingle: 80},
+		{ports: intstr.FromString("80"), expectedSingle: 80},
+		{ports: intstr.FromString("8000-8010"), expectedRange: "8000-8010"},
+		{ports: intstr.FromString("8000 - 8010"), expectedRange: "8000-8010"},
+		{ports: intstr.FromString("8000,8010"), expectedList: "8000,8010"},
+		{ports: intstr.FromString("8000, 8010"), expectedList: "8000,8010"},
+		{ports: intstr.FromString(" 80\t"), expectedSingle: 80},
+		{ports: intstr.FromString("8000,\t8010"), expectedList: "8000,8010"},
+		{ports: intstr.FromString("80 80")},
+	}
+
+	for _, test := range tests {
+		var single int32
+		var list, rangeField string
+		processPorts(test.ports, &single, &list, &rangeField)
+		assert.Equal(t, test.expectedSingle, single, test.ports.String())
+		assert.Equal(t, test.expectedList, list, test.ports.String())
+		assert.Equal(t, test.expectedRange, rangeField, test.ports.String())
+	}
+}
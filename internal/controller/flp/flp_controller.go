@@ -30,10 +30,11 @@ import (
 // Reconciler reconciles the current flowlogs-pipeline state with the desired configuration
 type Reconciler struct {
 	client.Client
-	mgr              *manager.Manager
-	watcher          *watchers.Watcher
-	status           status.Instance
-	currentNamespace string
+	mgr                *manager.Manager
+	watcher            *watchers.Watcher
+	status             status.Instance
+	currentNamespace   string
+	strippedFlowFields []string
 }
 
 func Start(ctx context.Context, mgr *manager.Manager) (manager.PostCreateHook, error) {
@@ -128,6 +129,11 @@ func (r *Reconciler) reconcile(ctx context.Context, clh *helper.Client, fc *flow
 
 	r.watcher.Reset(ns)
 
+	if !slices.Equal(fc.Spec.Processor.StrippedFlowFields, r.strippedFlowFields) {
+		log.Info("Flow fields stripped by flowlogs-pipeline", "fields", fc.Spec.Processor.StrippedFlowFields)
+		r.strippedFlowFields = fc.Spec.Processor.StrippedFlowFields
+	}
+
 	// Auto-detect subnets
 	var subnetLabels []flowslatest.SubnetLabel
 	if r.mgr.ClusterInfo.IsOpenShift() && fc.Spec.Processor.HasAutoDetectOpenShiftNetworks() {
@@ -270,6 +270,18 @@ func (b *PipelineBuilder) addTruncFiltersDedupStage(previous config.PipelineBuil
 		}
 		stage = stage.TransformFilter("dedup", transformFilter)
 	}
+
+	// Stripped fields: must come last, before writers
+	if len(b.desired.Processor.StrippedFlowFields) > 0 {
+		var rules []api.TransformFilterRule
+		for _, field := range b.desired.Processor.StrippedFlowFields {
+			rules = append(rules, api.TransformFilterRule{
+				Type:        api.RemoveField,
+				RemoveField: &api.TransformFilterGenericRule{Input: field},
+			})
+		}
+		stage = stage.TransformFilter("strip_fields", api.TransformFilter{Rules: rules})
+	}
 	return stage
 }
 
@@ -378,6 +390,13 @@ func (b *PipelineBuilder) addConnectionTracking(lastStage config.PipelineBuilder
 			Operation:     "first",
 			ReportMissing: true,
 		},
+		{
+			// Flows of a connection can be sampled at different intervals, such as when the sampling changes over time:
+			// the largest one is kept. It is only an upper bound of the sampling applied to the connection, so multiplying
+			// the connection counters by it can overestimate the traffic.
+			Name:      "Sampling",
+			Operation: "max",
+		},
 	}
 
 	if b.desired.Agent.EBPF.IsPktDropEnabled() {
@@ -86,6 +86,29 @@ func TestPipelineConfig(t *testing.T) {
 	)
 }
 
+func TestPipelineConnectionTrackingOutputFields(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := getConfig()
+
+	b := monoBuilder("namespace", &cfg)
+	scm, _, dcm, err := b.configMaps()
+	assert.NoError(err)
+	cfs, _ := validatePipelineConfig(t, scm, dcm)
+	assert.Equal("extract_conntrack", cfs.Parameters[1].Name)
+	outputFields := map[string]api.OutputField{}
+	for _, f := range cfs.Parameters[1].Extract.ConnTrack.OutputFields {
+		if !f.SplitAB {
+			outputFields[f.Name] = f
+		}
+	}
+	// First and last packet timestamps must be kept across the merged flows of a conversation
+	assert.Equal(api.OutputField{Name: "TimeFlowStartMs", Operation: "min", ReportMissing: true}, outputFields["TimeFlowStartMs"])
+	assert.Equal(api.OutputField{Name: "TimeFlowEndMs", Operation: "max", ReportMissing: true}, outputFields["TimeFlowEndMs"])
+	// The largest sampling interval is kept, as an upper bound
+	assert.Equal(api.OutputField{Name: "Sampling", Operation: "max"}, outputFields["Sampling"])
+}
+
 func TestPipelineTraceStage(t *testing.T) {
 	assert := assert.New(t)
 
@@ -408,6 +431,38 @@ func TestPipelineWithFilters_WantNamespacesABC(t *testing.T) {
 	)
 }
 
+func TestPipelineWithStrippedFields(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := flowslatest.FlowCollectorSpec{
+		Processor: flowslatest.FlowCollectorFLP{
+			StrippedFlowFields: []string{"DnsName", "SrcK8S_HostName"},
+		},
+	}
+
+	b := monoBuilder("namespace", &cfg)
+	scm, _, dcm, err := b.configMaps()
+	assert.NoError(err)
+	cfs, pipeline := validatePipelineConfig(t, scm, dcm)
+	assert.Equal(
+		`[{"name":"grpc"},{"name":"enrich","follows":"grpc"},{"name":"strip_fields","follows":"enrich"},{"name":"loki","follows":"strip_fields"},{"name":"prometheus","follows":"strip_fields"}]`,
+		pipeline,
+	)
+	assert.Equal(
+		[]api.TransformFilterRule{
+			{
+				Type:        api.RemoveField,
+				RemoveField: &api.TransformFilterGenericRule{Input: "DnsName"},
+			},
+			{
+				Type:        api.RemoveField,
+				RemoveField: &api.TransformFilterGenericRule{Input: "SrcK8S_HostName"},
+			},
+		},
+		cfs.Parameters[2].Transform.Filter.Rules,
+	)
+}
+
 func TestPipelineWithFilters_DontWantNamespacesABC_LokiOnly(t *testing.T) {
 	assert := assert.New(t)
 
@@ -117,6 +117,7 @@ func GetAdvancedAgentConfig(specConfig *flowslatest.AdvancedAgentConfig) flowsla
 				cfg.Scheduling.PriorityClassName = specConfig.Scheduling.PriorityClassName
 			}
 		}
+		cfg.MaxProcessingCPUs = specConfig.MaxProcessingCPUs
 	}
 
 	return cfg
@@ -5,8 +5,11 @@ import (
 	"fmt"
 
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
+	pluginconfig "github.com/netobserv/network-observability-operator/internal/controller/consoleplugin/config"
 	"github.com/netobserv/network-observability-operator/internal/pkg/cluster"
 	"github.com/netobserv/network-observability-operator/internal/pkg/manager/status"
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics"
 	"github.com/netobserv/network-observability-operator/internal/pkg/migrator"
 	"github.com/netobserv/network-observability-operator/internal/pkg/narrowcache"
 	"k8s.io/client-go/discovery"
@@ -107,6 +110,9 @@ func NewManager(
 		return nil, fmt.Errorf("can't collect cluster info: %w", err)
 	}
 	flowslatest.CurrentClusterInfo = info
+	flowslatest.FlowFieldsUsage = func(ctx context.Context, fc *flowslatest.FlowCollectorSpec) map[string][]string {
+		return getFlowFieldsUsage(ctx, client, fc)
+	}
 
 	this := &Manager{
 		Manager:     internalManager,
@@ -149,3 +155,25 @@ func NewManager(
 func (m *Manager) GetClient() client.Client {
 	return m.Client
 }
+
+// getFlowFieldsUsage returns the flow fields used by the enabled metrics, including the FlowMetric resources, and by the console plugin columns.
+func getFlowFieldsUsage(ctx context.Context, c client.Client, fc *flowslatest.FlowCollectorSpec) map[string][]string {
+	usage := make(map[string][]string)
+	fms := metricslatest.FlowMetricList{}
+	if err := c.List(ctx, &fms, &client.ListOptions{Namespace: fc.GetNamespace()}); err != nil {
+		log.FromContext(ctx).Error(err, "could not list FlowMetrics")
+	}
+	for field, names := range metrics.GetFieldsUsage(metrics.MergePredefined(fms.Items, fc)) {
+		for _, name := range names {
+			usage[field] = append(usage[field], "metric "+name)
+		}
+	}
+	if cfg, err := pluginconfig.GetStaticFrontendConfig(); err == nil {
+		for field, names := range cfg.GetColumnsFieldsUsage() {
+			for _, name := range names {
+				usage[field] = append(usage[field], "console plugin column "+name)
+			}
+		}
+	}
+	return usage
+}
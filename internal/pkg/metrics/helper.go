@@ -1,6 +1,9 @@
 package metrics
 
 import (
+	"slices"
+	"strings"
+
 	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
 )
 
@@ -21,3 +24,23 @@ func GetFilters(fm *metricslatest.FlowMetricSpec) []metricslatest.MetricFilter {
 	}
 	return append(fm.Filters, filters...)
 }
+
+// GetFieldsUsage returns the flow fields used by the metrics, as labels, filters or values, mapped to the names of the metrics using them.
+func GetFieldsUsage(fms []metricslatest.FlowMetric) map[string][]string {
+	usage := make(map[string][]string)
+	for i := range fms {
+		spec := &fms[i].Spec
+		fields := append([]string{spec.ValueField}, spec.Labels...)
+		for _, f := range spec.Filters {
+			fields = append(fields, f.Field)
+		}
+		for _, f := range fields {
+			// Split field for nesting, e.g. "NetworkEvents>Name"
+			f = strings.Split(f, ">")[0]
+			if f != "" && !slices.Contains(usage[f], spec.MetricName) {
+				usage[f] = append(usage[f], spec.MetricName)
+			}
+		}
+	}
+	return usage
+}